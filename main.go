@@ -1,33 +1,66 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/heckdevice/goactorframework-corelib"
 	"github.com/heckdevice/goactorframework-examples/samples"
 )
 
+const (
+	// shutdownTimeout - time the actors get to drain their mailboxes on shutdown before being stopped forcibly
+	shutdownTimeout = time.Second * 5
+)
+
 var (
-	killPill         = make(chan os.Signal)
-	terminateProcess = make(chan bool)
+	killPill    = make(chan os.Signal, 1)
+	verbose     = flag.Bool("verbose", false, "log every message received and processed by the actors, on top of their life cycle")
+	metricsAddr = flag.String("metrics-addr", "", "address to serve the Prometheus metrics of the actor system on, such as localhost:9090, not served if empty")
 )
 
 func main() {
+	flag.Parse()
+	logLevel := core.InfoLevel
+	if *verbose {
+		logLevel = core.DebugLevel
+	}
+	opts := []core.Option{core.WithLogger(core.NewStdLogger(log.New(os.Stderr, "", log.LstdFlags), logLevel))}
+	if len(*metricsAddr) != 0 {
+		registry := core.NewPrometheusRegistry()
+		opts = append(opts, core.WithMetrics(registry))
+		go serveMetrics(*metricsAddr, registry)
+	}
+	err := core.ConfigureDefaultActorSystem(opts...)
+	if err != nil {
+		log.Panic(fmt.Sprintf("Error while configuring the default actor system. Details : %v", err.Error()))
+	}
 	signal.Notify(killPill, os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGSTOP, syscall.SIGTSTP)
 	oncomingMessages := samples.InitSampleMessageQueue()
 	core.GetDefaultActorSystem().Start(oncomingMessages)
-	for {
-		select {
-		case <-killPill:
-			fmt.Println(fmt.Sprintf("\n\n******--- Shutting down due to SIGTERM ---******"))
-			core.GetDefaultActorSystem().Close(terminateProcess)
-		case <-terminateProcess:
-			fmt.Println(fmt.Sprintf("\n\n******--- Actor system is stopped, exiting ---******"))
-			return
-		}
+	<-killPill
+	fmt.Println(fmt.Sprintf("\n\n******--- Shutting down due to SIGTERM ---******"))
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	report, err := core.GetDefaultActorSystem().Shutdown(ctx)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("Actors did not drain in time. Details : %v", err.Error()))
 	}
+	fmt.Println(fmt.Sprintf("\n\n******--- Actor system is stopped, exiting (%v messages drained, %v abandoned) ---******", report.Drained(), report.Abandoned()))
+}
 
+// serveMetrics - Serves the metrics of the registry on /metrics of the address
+func serveMetrics(addr string, registry *core.PrometheusRegistry) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("Serving the metrics on %v failed. Details : %v", addr, err.Error())
+	}
 }
@@ -1,6 +1,8 @@
 package samples
 
 import (
+	"fmt"
+	"log"
 	"math/rand"
 	"time"
 
@@ -10,6 +12,15 @@ import (
 	"github.com/heckdevice/goactorframework-examples/samples/printmessage"
 )
 
+const (
+	// pumpInterval - interval at which the sample messages are produced
+	pumpInterval = time.Millisecond * 50
+	// pumpActorType - actor type of the actor producing the sample messages
+	pumpActorType = "MessagePump"
+	// messageTypeTICK - messageType scheduled repeatedly to the pump actor, every tick produces one batch of sample messages
+	messageTypeTICK = "TICK"
+)
+
 var (
 	messageQueue = make(chan core.Message, 10)
 )
@@ -18,23 +29,56 @@ var (
 func InitSampleMessageQueue() chan core.Message {
 	printmessage.InitActor()
 	echomessage.InitActor()
-	go pumpMessages()
+	initPumpActor()
 	return messageQueue
 }
 
-func pumpMessages() {
+// initPumpActor - Registers and spawns the pump actor, and schedules its ticks every pumpInterval through the scheduler of the Default actor system.
+// The ticks are cancelled automatically once the pump actor stops
+func initPumpActor() {
+	pumpActor := core.Actor{ActorType: pumpActorType}
+	err := core.GetDefaultActorSystem().RegisterActor(&pumpActor, messageTypeTICK, pumpMessages)
+	if err != nil {
+		log.Panic(fmt.Sprintf("Error while registering actor %v. Details : %v", pumpActor.ActorType, err.Error()))
+	}
+	go pumpActor.SpawnActor()
+	pump := core.ActorReference{ActorType: pumpActorType}
+	core.GetDefaultActorSystem().ScheduleRepeatedly(0, pumpInterval, pump, core.Message{MessageType: messageTypeTICK, Sender: &pump})
+}
+
+// pumpMessages - Produces one batch of sample messages into the message queue on every tick
+func pumpMessages(tick core.Message) {
 	dummySender := core.ActorReference{}
 	dummySender.ActorType = "ActorSystem"
-	for {
-		messageQueue <- core.Message{MessageType: common.ConsolePrint,
+	batch := []core.Message{
+		{MessageType: common.ConsolePrint,
 			Mode:        core.Broadcast,
 			Sender:      &dummySender,
 			Payload:     map[string]interface{}{"data": rand.Int()},
-			BroadcastTo: []*core.ActorReference{{ActorType: printmessage.ActorType}, {ActorType: echomessage.ActorType}}}
-		messageQueue <- core.Message{MessageType: common.ConsolePrint, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}}
-		messageQueue <- core.Message{MessageType: common.ConsolePrint, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: printmessage.ActorType}}
-		messageQueue <- core.Message{MessageType: echomessage.MessageTypeHI, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}}
-		messageQueue <- core.Message{MessageType: echomessage.MessageTypeBYE, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}}
-		time.Sleep(time.Millisecond * 50)
+			BroadcastTo: []*core.ActorReference{{ActorType: printmessage.ActorType}, {ActorType: echomessage.ActorType}}},
+		{MessageType: common.ConsolePrint, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}},
+		{MessageType: common.ConsolePrint, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: printmessage.ActorType}},
+		//HI and BYE go through the one queue so the GreetingActor always gets greeted before being bid bye
+		{MessageType: echomessage.MessageTypeHI, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}},
+		{MessageType: echomessage.MessageTypeBYE, Mode: core.Unicast, Sender: &dummySender, Payload: map[string]interface{}{"data": rand.Int()}, UnicastTo: &core.ActorReference{ActorType: echomessage.ActorType}},
+	}
+	for _, message := range batch {
+		select {
+		case messageQueue <- message:
+		default:
+			//the handler never blocks the pump actor, the rest of the batch is skipped while the dispatcher lags behind
+			log.Print("Message queue is full, skipping the rest of the batch")
+			return
+		}
+	}
+	go askHowAreYou()
+}
+
+func askHowAreYou() {
+	reply, err := core.GetDefaultActorSystem().Ask(core.ActorReference{ActorType: echomessage.ActorType}, echomessage.MessageTypeHOWAREYOU, nil, time.Second).Result()
+	if err != nil {
+		log.Printf("Asking %v how it is doing failed. Details : %v", echomessage.ActorType, err.Error())
+		return
 	}
+	fmt.Print(fmt.Sprintf("%v replied : %v", echomessage.ActorType, reply))
 }
@@ -15,28 +15,59 @@ const (
 	MessageTypeHI = "HI"
 	// MessageTypeBYE - supported messageType for this actor
 	MessageTypeBYE = "BYE"
+	// MessageTypeHOWAREYOU - supported request/response messageType for this actor, to be sent through Ask
+	MessageTypeHOWAREYOU = "HOWAREYOU"
 )
 
-// InitActor - Initialises this actor by registering its different message handlers and spawing the actor using the Default actor system
+// InitActor - Initialises this actor by registering its different message handlers and spawing the actor using the Default actor system.
+// The actor starts off "not greeted" and becomes "greeted" on a HI till it is bid BYE
 func InitActor() {
 	greetingActor := core.Actor{ActorType: ActorType}
-	err := core.GetDefaultActorSystem().RegisterActor(&greetingActor, MessageTypeHI, greetHI)
+	err := core.GetDefaultActorSystem().RegisterContextActor(&greetingActor, MessageTypeHI, greetHI)
 	if err != nil {
 		log.Panic(fmt.Sprintf("Error while registering actor %v. Details : %v", greetingActor.ActorType, err.Error()))
 	}
-	greetingActor.RegisterMessageHandler(MessageTypeBYE, greetBye)
+	greetingActor.RegisterContextHandler(MessageTypeBYE, byeBeforeHI)
 	greetingActor.RegisterMessageHandler(common.ConsolePrint, consolePrint)
+	greetingActor.RegisterContextHandler(MessageTypeHOWAREYOU, howAreYou)
 	go greetingActor.SpawnActor()
 }
 
-func greetHI(message core.Message) {
+// greeted - Behaviour of this actor once it has been greeted, till it is bid goodbye
+func greeted() core.Behaviour {
+	return core.Behaviour{
+		MessageTypeHI:        alreadyGreeted,
+		MessageTypeBYE:       greetBye,
+		common.ConsolePrint:  core.Adapt(consolePrint),
+		MessageTypeHOWAREYOU: howAreYou,
+	}
+}
+
+func greetHI(ctx core.ActorContext, message core.Message) {
 	fmt.Print(fmt.Sprintf("Hi there %v, i got %v", message.Sender.ActorType, message.Payload))
+	ctx.Become(greeted(), false)
 }
 
-func greetBye(message core.Message) {
+func alreadyGreeted(ctx core.ActorContext, message core.Message) {
+	fmt.Print(fmt.Sprintf("We have already said hi %v", message.Sender.ActorType))
+}
+
+func greetBye(ctx core.ActorContext, message core.Message) {
 	fmt.Print(fmt.Sprintf("Adios %v !!!", message.Sender.ActorType))
+	ctx.Unbecome()
+}
+
+func byeBeforeHI(ctx core.ActorContext, message core.Message) {
+	fmt.Print(fmt.Sprintf("Adios %v, though we never said hi !!!", message.Sender.ActorType))
 }
 
 func consolePrint(message core.Message) {
 	fmt.Print(fmt.Sprintf("Echo : %v", message))
 }
+
+func howAreYou(ctx core.ActorContext, message core.Message) {
+	err := ctx.Reply(fmt.Sprintf("I am %v and doing great %v, thanks for asking", ctx.Self().ActorType, ctx.Sender().ActorType))
+	if err != nil {
+		ctx.Logger().Warn("Could not reply", core.Field{Key: "sender", Value: ctx.Sender().ActorType}, core.Field{Key: "error", Value: err})
+	}
+}
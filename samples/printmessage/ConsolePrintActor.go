@@ -11,12 +11,14 @@ import (
 const (
 	// ActorType - actor type for this actor
 	ActorType = "PrintActor"
+	// Instances - number of PrintActor instances in the pool sharing the console printing load
+	Instances = 3
 )
 
-// InitActor - Initialises this actor by registering its different message handlers and spawing the actor using the Default actor system
+// InitActor - Initialises this actor as a round robin pool by registering its different message handlers and spawing the routees using the Default actor system
 func InitActor() {
-	printActor := core.Actor{ActorType: ActorType}
-	err := core.GetDefaultActorSystem().RegisterActor(&printActor, common.ConsolePrint, consolePrint)
+	printActor := core.Router{Actor: core.Actor{ActorType: ActorType}, Instances: Instances, Logic: core.RoundRobinRouting}
+	err := core.GetDefaultActorSystem().RegisterRouter(&printActor, common.ConsolePrint, consolePrint)
 	if err != nil {
 		log.Panic(fmt.Sprintf("Error while registering actor %v. Details : %v", printActor.ActorType, err.Error()))
 	}